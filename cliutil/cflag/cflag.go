@@ -60,6 +60,21 @@ type CFlags struct {
 	// remainArgs after binding args
 	remainArgs []string
 
+	// parent command, nil on the root command
+	parent *CFlags
+	// cmdNames keep the registration order of commands
+	cmdNames []string
+	// commands sub command table, key is the command name
+	commands map[string]*cmdEntry
+	// cmdWidth max command name width, for help align
+	cmdWidth int
+
+	// envPrefix enable loading flag values from ENV with this prefix, set by WithEnvPrefix
+	envPrefix string
+
+	// hiddenOpts option names excluded from ShowHelp, eg: internal completion flags
+	hiddenOpts map[string]bool
+
 	// Desc command description
 	Desc string
 	// Version command version number
@@ -119,6 +134,14 @@ func WithVersion(version string) func(c *CFlags) {
 	}
 }
 
+// WithEnvPrefix enable loading flag values from ENV vars with the given
+// prefix. eg: with prefix "APP", option `--foo-bar` maps to env `APP_FOO_BAR`.
+func WithEnvPrefix(prefix string) func(c *CFlags) {
+	return func(c *CFlags) {
+		c.envPrefix = strings.ToUpper(strings.Trim(prefix, "_"))
+	}
+}
+
 // WithConfigFn for command
 func (c *CFlags) WithConfigFn(fns ...func(c *CFlags)) *CFlags {
 	for _, fn := range fns {
@@ -221,19 +244,47 @@ func (c *CFlags) Parse(args []string) error {
 		}
 	}()
 
+	// runtime shell completion request, handled before anything else.
+	if c.isCompletionRequest(args) {
+		c.runCompletion(args)
+		return nil
+	}
+
 	// prepare
 	if err := c.prepare(); err != nil {
 		return err
 	}
 
-	// do parsing
-	if err := c.doParse(args); err != nil {
+	// do parsing. dispatched is true once a registered subcommand has taken
+	// over and already run to completion, nothing below should run again.
+	dispatched, err := c.doParse(args)
+	if dispatched {
+		return err
+	}
+	if err != nil {
 		if err == flag.ErrHelp {
 			return nil // ignore help error
 		}
 		return err
 	}
 
+	// generate and print a completion script, instead of running Func.
+	if shell := c.Lookup(flagCompletionScript).Value.String(); shell != "" {
+		return c.GenCompletion(shell, os.Stdout)
+	}
+
+	// dump the current flag values as a config file, instead of running Func.
+	if c.Lookup(flagDumpConfig).Value.String() == "true" {
+		return c.WriteConfig(os.Stdout, ConfigIni)
+	}
+
+	// --help-format alone (without -h/--help) also renders help, instead of
+	// running Func, so `mycmd --help-format=man | man -l -` works as documented.
+	if format := c.helpFormat(); format != "" && format != "text" {
+		c.renderHelpInFormat(format)
+		return nil
+	}
+
 	// call func
 	if c.Func != nil {
 		return c.Func(c)
@@ -245,6 +296,13 @@ func (c *CFlags) prepare() error {
 	// dont use flag output.
 	c.SetOutput(ioutil.Discard)
 
+	// register hidden flags used for shell completion
+	c.registerCompletionFlags()
+	// register hidden flag used to pick the help renderer
+	c.registerHelpFormatFlag()
+	// register hidden flag used to dump the current config
+	c.registerDumpConfigFlag()
+
 	// parse flag usage string
 	c.VisitAll(func(f *flag.Flag) {
 		if regName, ok := c.shortcuts[f.Name]; ok {
@@ -254,6 +312,11 @@ func (c *CFlags) prepare() error {
 		f.Usage = c.parseFlagUsage(f.Name, f.Usage)
 	})
 
+	// apply values from env, lower priority than CLI args, higher than config file.
+	if c.envPrefix != "" {
+		c.applyEnvOverrides()
+	}
+
 	// custom something
 	c.FlagSet.Usage = c.ShowHelp
 	return nil
@@ -293,23 +356,38 @@ func (c *CFlags) parseFlagUsage(name, usage string) string {
 	return desc
 }
 
-// do parse and validate
-func (c *CFlags) doParse(args []string) error {
+// do parse and validate. dispatched reports whether a registered subcommand
+// matched and already ran via its own Parse - in that case err is the
+// subcommand's result and the caller must not fall through to bindParsedArgs
+// or c.Func, since the remaining args belong to the subcommand, not to c.
+func (c *CFlags) doParse(args []string) (dispatched bool, err error) {
 	if len(c.shortcuts) > 0 && len(args) > 0 {
 		args = c.replaceShorts(args)
 	}
 
-	// do parsing
-	if err := c.FlagSet.Parse(args); err != nil {
-		return err
+	// do parsing. flag.FlagSet.Parse stops at the first non-flag argument,
+	// so options given before the subcommand name (eg: `mycmd --output
+	// foo.txt build`) are parsed here with their values, not mistaken for it.
+	if err = c.FlagSet.Parse(args); err != nil {
+		return false, err
+	}
+
+	// dispatch to a matched subcommand using the remaining positional args,
+	// before enforcing the root's own Required options - a required root
+	// option must not block reaching eg: `bin sub --help`. The subcommand
+	// gets its own checkBindOpts via its own Parse.
+	if len(c.commands) > 0 {
+		if name, rest, ok := c.splitCommand(c.Args()); ok {
+			return true, c.dispatchCommand(name, rest)
+		}
 	}
 
 	// check option values
-	if err := c.checkBindOpts(); err != nil {
-		return err
+	if err = c.checkBindOpts(); err != nil {
+		return false, err
 	}
 
-	return c.bindParsedArgs()
+	return false, c.bindParsedArgs()
 }
 
 // replace shorts to full option. will stop on '--'
@@ -441,6 +519,11 @@ func (c *CFlags) ShowHelp() {
 
 // show help for command
 func (c *CFlags) showHelp(err error) {
+	if format := c.helpFormat(); format != "" && format != "text" {
+		c.renderHelpInFormat(format)
+		return
+	}
+
 	binName := c.Name()
 	helpVars := map[string]string{
 		"{{cmd}}":     binName,
@@ -457,12 +540,21 @@ func (c *CFlags) showHelp(err error) {
 		buf.QuietWritef("<cyan>%s</>\n\n", c.helpDesc())
 	}
 
-	buf.QuietWritef("<comment>Usage:</> %s [--Options...] [...Arguments]\n", binName)
+	if len(c.commands) > 0 {
+		buf.QuietWritef("<comment>Usage:</> %s [--Options...] COMMAND [--Options...] [...Arguments]\n", binName)
+	} else {
+		buf.QuietWritef("<comment>Usage:</> %s [--Options...] [...Arguments]\n", binName)
+	}
 	buf.QuietWriteString("<comment>Options:</>\n")
 
 	// render options help
 	c.renderOptionsHelp(buf)
 
+	if len(c.commands) > 0 {
+		buf.QuietWriteString("\n<comment>Commands:</>\n")
+		c.renderCommandsHelp(buf)
+	}
+
 	if len(c.bindArgs) > 0 {
 		buf.QuietWriteString("\n<comment>Arguments:</>\n")
 		for name, arg := range c.bindArgs {
@@ -490,6 +582,9 @@ func (c *CFlags) showHelp(err error) {
 // from flag.PrintDefaults
 func (c *CFlags) renderOptionsHelp(buf *strutil.Buffer) {
 	c.VisitAll(func(opt *flag.Flag) {
+		if c.hiddenOpts[opt.Name] {
+			return
+		}
 		var b strings.Builder
 
 		mate := c.bindOpts[opt.Name]
@@ -500,6 +595,9 @@ func (c *CFlags) renderOptionsHelp(buf *strutil.Buffer) {
 			b.WriteString(" ")
 			b.WriteString(typName)
 		}
+		if mate.Repeatable {
+			b.WriteString(" (repeatable)")
+		}
 
 		// Boolean flags of one ASCII letter are so common we
 		// treat them specially, putting their usage on the same line.
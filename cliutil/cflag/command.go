@@ -0,0 +1,126 @@
+package cflag
+
+import (
+	"flag"
+
+	"github.com/gookit/goutil/mathutil"
+	"github.com/gookit/goutil/stdutil"
+	"github.com/gookit/goutil/strutil"
+)
+
+// cmdEntry keep a registered subcommand and its runtime command instance.
+type cmdEntry struct {
+	// Name of the command
+	Name string
+	// Desc of the command
+	Desc string
+	// sub command instance
+	sub *CFlags
+}
+
+// AddCommand binding a subcommand by name, returns the child *CFlags so
+// that caller can continue to bind options and arguments on it.
+//
+// The child keeps its own FlagSet, so it does not need to redeclare the
+// parent's global options to read them: the parent's flags are always
+// parsed before a subcommand is dispatched, and the child can reach their
+// values through Parent()/Root() or LookupOpt, which walks up the command
+// tree. The parent's Required options and Validators are not checked when
+// a subcommand is dispatched.
+//
+// Usage:
+//
+//	cmd := cflag.New()
+//	sub := cmd.AddCommand("serve", "start the http server", func(c *cflag.CFlags) error {
+//		return nil
+//	})
+//	sub.StringVar(&addr, "addr", ":8080", "listen address")
+//
+//	cmd.MustParse(nil)
+func (c *CFlags) AddCommand(name, desc string, fn func(*CFlags) error) *CFlags {
+	if name == "" {
+		stdutil.Panicf("cflag: command name cannot be empty")
+	}
+
+	if c.commands == nil {
+		c.commands = make(map[string]*cmdEntry)
+	}
+	if _, ok := c.commands[name]; ok {
+		stdutil.Panicf("cflag: command '%s' have been registered", name)
+	}
+
+	sub := NewEmpty(func(sc *CFlags) {
+		sc.FlagSet = flag.NewFlagSet(c.Name()+" "+name, flag.ContinueOnError)
+		sc.parent = c
+		sc.Desc = desc
+		sc.Func = fn
+	})
+
+	c.commands[name] = &cmdEntry{Name: name, Desc: desc, sub: sub}
+	c.cmdNames = append(c.cmdNames, name)
+	c.cmdWidth = mathutil.MaxInt(c.cmdWidth, len(name))
+	return sub
+}
+
+// Parent command instance. returns nil on the root command.
+func (c *CFlags) Parent() *CFlags {
+	return c.parent
+}
+
+// Root command instance. returns itself if it is already the root command.
+func (c *CFlags) Root() *CFlags {
+	root := c
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// Commands registered on the current command, key is the command name.
+func (c *CFlags) Commands() map[string]*CFlags {
+	cs := make(map[string]*CFlags, len(c.commands))
+	for name, ent := range c.commands {
+		cs[name] = ent.sub
+	}
+	return cs
+}
+
+// LookupOpt the flag.Flag bound to name on c, falling back to Parent() and
+// so on up to Root(). Use this from a subcommand to read a global option
+// that was parsed on the root before this command was dispatched.
+func (c *CFlags) LookupOpt(name string) *flag.Flag {
+	if f := c.Lookup(name); f != nil {
+		return f
+	}
+	if c.parent != nil {
+		return c.parent.LookupOpt(name)
+	}
+	return nil
+}
+
+// splitCommand checks args[0] - the first positional arg left over after
+// c.FlagSet.Parse - against the registered subcommand table. On a match it
+// returns the command name and the remaining args to hand off to the
+// child's Parse.
+func (c *CFlags) splitCommand(args []string) (name string, rest []string, ok bool) {
+	if len(args) == 0 {
+		return "", nil, false
+	}
+	if _, exist := c.commands[args[0]]; exist {
+		return args[0], args[1:], true
+	}
+	return "", nil, false
+}
+
+// dispatchCommand runs the matched subcommand with the remaining args.
+func (c *CFlags) dispatchCommand(name string, rest []string) error {
+	return c.commands[name].sub.Parse(rest)
+}
+
+// renderCommandsHelp render the "Commands:" section for ShowHelp
+func (c *CFlags) renderCommandsHelp(buf *strutil.Buffer) {
+	for _, name := range c.cmdNames {
+		ent := c.commands[name]
+		buf.QuietWritef("  <green>%s</>   %s\n", strutil.PadRight(name, " ", c.cmdWidth), ent.Desc)
+	}
+}
@@ -0,0 +1,227 @@
+package cflag
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gookit/goutil/errorx"
+)
+
+// supported shells for GenCompletion
+const (
+	ShellBash = "bash"
+	ShellZsh  = "zsh"
+	ShellFish = "fish"
+)
+
+// hidden flag names used to drive shell completion
+const (
+	flagCompletionScript = "completion-script"
+	flagComplete         = "_complete"
+)
+
+const bashCompletionTpl = `_%[1]s_complete() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    COMPREPLY=( $(COMP_LINE="$COMP_LINE" %[1]s --_complete "$COMP_LINE") )
+}
+complete -F _%[1]s_complete %[1]s
+`
+
+const zshCompletionTpl = `#compdef %[1]s
+_%[1]s() {
+    local -a completions
+    completions=("${(@f)$(%[1]s --_complete "$words")}")
+    _describe '%[1]s' completions
+}
+compdef _%[1]s %[1]s
+`
+
+const fishCompletionTpl = `function __complete_%[1]s
+    %[1]s --_complete (commandline -cp)
+end
+complete -c %[1]s -f -a '(__complete_%[1]s)'
+`
+
+// GenCompletion write a bash, zsh or fish completion script for the command
+// to w. The generated script shells out to `<bin> --_complete "<line>"` for
+// candidates, see runCompletion.
+func (c *CFlags) GenCompletion(shell string, w io.Writer) error {
+	var tpl string
+	switch shell {
+	case ShellBash:
+		tpl = bashCompletionTpl
+	case ShellZsh:
+		tpl = zshCompletionTpl
+	case ShellFish:
+		tpl = fishCompletionTpl
+	default:
+		return errorx.Rawf("cflag: unsupported completion shell '%s'", shell)
+	}
+
+	_, err := fmt.Fprintf(w, tpl, c.Name())
+	return err
+}
+
+// registerCompletionFlags install the hidden flags that drive completion.
+// Called from prepare(), so every command (including subcommands) gets them.
+func (c *CFlags) registerCompletionFlags() {
+	if c.hiddenOpts == nil {
+		c.hiddenOpts = make(map[string]bool)
+	}
+
+	if c.Lookup(flagCompletionScript) == nil {
+		c.String(flagCompletionScript, "", "Generate shell completion script(bash|zsh|fish)")
+		c.hiddenOpts[flagCompletionScript] = true
+	}
+	if c.Lookup(flagComplete) == nil {
+		c.String(flagComplete, "", "Internal flag for runtime shell completion")
+		c.hiddenOpts[flagComplete] = true
+	}
+}
+
+// isCompletionRequest report whether args is asking for runtime completion,
+// either via the COMP_LINE env var(bash/zsh) or the hidden --_complete flag.
+func (c *CFlags) isCompletionRequest(args []string) bool {
+	if os.Getenv("COMP_LINE") != "" {
+		return true
+	}
+
+	full := AddPrefix(flagComplete)
+	for _, a := range args {
+		if a == full || strings.HasPrefix(a, full+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// runCompletion parse the partial command line and print one completion
+// candidate per line to stdout.
+func (c *CFlags) runCompletion(args []string) {
+	line := os.Getenv("COMP_LINE")
+	if line == "" {
+		line = completionLineArg(args)
+	}
+
+	words := strings.Fields(line)
+	if len(words) > 0 {
+		words = words[1:] // drop the program name
+	}
+
+	cur := ""
+	if len(words) > 0 && !strings.HasSuffix(line, " ") {
+		cur = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	// walk into the subcommand the already-typed words point at, if any, so
+	// completion is resolved against its own bindOpts/shortcuts/subcommands
+	// instead of always the root's.
+	target, words := c.resolveCompletionTarget(words)
+	for _, cand := range target.collectCandidates(words, cur) {
+		fmt.Println(cand)
+	}
+}
+
+// resolveCompletionTarget consume the leading words that name registered
+// subcommands, descending into each one in turn, and returns the deepest
+// matching *CFlags plus the words left over for it to interpret.
+func (c *CFlags) resolveCompletionTarget(words []string) (*CFlags, []string) {
+	cur := c
+	i := 0
+	for i < len(words) {
+		w := words[i]
+		if w == "" || w[0] == '-' {
+			break
+		}
+
+		ent, ok := cur.commands[w]
+		if !ok {
+			break
+		}
+		cur = ent.sub
+		i++
+	}
+	return cur, words[i:]
+}
+
+// completionLineArg extract the partial line passed to `--_complete`,
+// either as a separate word or as `--_complete=<line>`.
+func completionLineArg(args []string) string {
+	full := AddPrefix(flagComplete)
+	for i, a := range args {
+		if strings.HasPrefix(a, full+"=") {
+			return strings.TrimPrefix(a, full+"=")
+		}
+		if a == full && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// collectCandidates for the word being completed, given the already
+// completed words before it.
+func (c *CFlags) collectCandidates(words []string, cur string) []string {
+	// completing the value of an option with a custom Completer attached.
+	if len(words) > 0 {
+		if name := c.optNameFor(words[len(words)-1]); name != "" {
+			if opt, ok := c.bindOpts[name]; ok && opt.Completer != nil {
+				return opt.Completer(cur)
+			}
+		}
+	}
+
+	if strings.HasPrefix(cur, "-") {
+		return c.completeOptionNames(cur)
+	}
+	return c.completeCommandNames(cur)
+}
+
+// optNameFor resolve a "-x"/"--name" token to its registered flag name.
+func (c *CFlags) optNameFor(tok string) string {
+	if len(tok) == 0 || tok[0] != '-' {
+		return ""
+	}
+
+	name := strings.TrimLeft(tok, "-")
+	if full, ok := c.shortcuts[name]; ok {
+		return full
+	}
+	if c.Lookup(name) != nil {
+		return name
+	}
+	return ""
+}
+
+func (c *CFlags) completeOptionNames(cur string) []string {
+	var out []string
+	c.VisitAll(func(f *flag.Flag) {
+		if c.hiddenOpts[f.Name] {
+			return
+		}
+		if full := AddPrefix(f.Name); strings.HasPrefix(full, cur) {
+			out = append(out, full)
+		}
+	})
+
+	for short := range c.shortcuts {
+		if full := AddPrefix(short); strings.HasPrefix(full, cur) {
+			out = append(out, full)
+		}
+	}
+	return out
+}
+
+func (c *CFlags) completeCommandNames(cur string) []string {
+	var out []string
+	for _, name := range c.cmdNames {
+		if strings.HasPrefix(name, cur) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
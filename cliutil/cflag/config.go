@@ -0,0 +1,151 @@
+package cflag
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/goutil/errorx"
+)
+
+// config file formats supported by LoadConfig/LoadConfigFrom/WriteConfig
+const (
+	ConfigIni  = "ini"
+	ConfigYaml = "yaml"
+)
+
+// hidden flag that dumps the current flag values as a config file, see Parse
+const flagDumpConfig = "dump-config"
+
+// registerDumpConfigFlag install the hidden --dump-config flag, so users can
+// run `mycmd --dump-config > config.ini` to seed a config file for LoadConfig.
+func (c *CFlags) registerDumpConfigFlag() {
+	if c.hiddenOpts == nil {
+		c.hiddenOpts = make(map[string]bool)
+	}
+
+	if c.Lookup(flagDumpConfig) == nil {
+		c.Bool(flagDumpConfig, false, "Dump current flag values as an ini config and exit, eg: mycmd --dump-config > config.ini")
+		c.hiddenOpts[flagDumpConfig] = true
+	}
+}
+
+// LoadConfig read flag values from a INI or YAML file, the format is guessed
+// from the file extension(.ini, .yaml, .yml). Values loaded here are used as
+// new defaults: config file < ENV(see WithEnvPrefix) < CLI args.
+func (c *CFlags) LoadConfig(path string) error {
+	format := ConfigIni
+	if ext := strings.TrimLeft(filepath.Ext(path), "."); ext == "yaml" || ext == "yml" {
+		format = ConfigYaml
+	}
+
+	fh, err := os.Open(path)
+	if err != nil {
+		return errorx.Raw(err.Error())
+	}
+	defer fh.Close()
+
+	return c.LoadConfigFrom(fh, format)
+}
+
+// LoadConfigFrom read flag values from r, format is "ini" or "yaml".
+func (c *CFlags) LoadConfigFrom(r io.Reader, format string) error {
+	kv, err := parseConfigData(r, format)
+	if err != nil {
+		return err
+	}
+
+	for name, val := range kv {
+		fv := c.Lookup(name)
+		if fv == nil {
+			return errorx.Rawf("cflag: config key '%s' is not a registered option", name)
+		}
+		if err := fv.Value.Set(val); err != nil {
+			return errorx.Rawf("cflag: config key '%s': %s", name, err.Error())
+		}
+	}
+	return nil
+}
+
+// WriteConfig serialize the current flag values with their descriptions as
+// comments, format is "ini" or "yaml". Usable as: `mycmd --dump-config > cfg.ini`
+func (c *CFlags) WriteConfig(w io.Writer, format string) error {
+	var err error
+	c.VisitAll(func(f *flag.Flag) {
+		if err != nil {
+			return
+		}
+		// skip internal flags, eg: dump-config, completion-script
+		if c.hiddenOpts[f.Name] {
+			return
+		}
+
+		desc := color.ClearTag(f.Usage)
+		if opt, ok := c.bindOpts[f.Name]; ok && opt.Required {
+			desc = "(required) " + desc
+		}
+
+		switch format {
+		case ConfigYaml:
+			_, err = fmt.Fprintf(w, "# %s\n%s: %q\n", desc, f.Name, f.Value.String())
+		default:
+			_, err = fmt.Fprintf(w, "; %s\n%s = %s\n", desc, f.Name, f.Value.String())
+		}
+	})
+	return err
+}
+
+// applyEnvOverrides set flag values from ENV, for options not yet touched by
+// a config file this is also the default; CLI args parsed after this win.
+func (c *CFlags) applyEnvOverrides() {
+	c.VisitAll(func(f *flag.Flag) {
+		key := c.envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if val, ok := os.LookupEnv(key); ok {
+			_ = f.Value.Set(val)
+		}
+	})
+}
+
+// parseConfigData parse a simple flat "key = value"(ini) or "key: value"(yaml)
+// document into a map. Section headers in ini are ignored, since cflag options
+// are flat. This intentionally supports only scalar values, matching what a
+// CFlags option can bind.
+func parseConfigData(r io.Reader, format string) (map[string]string, error) {
+	kv := make(map[string]string)
+	sep := "="
+	if format == ConfigYaml {
+		sep = ":"
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		// ignore ini section headers, eg: [server]
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			continue
+		}
+
+		pos := strings.Index(line, sep)
+		if pos < 0 {
+			return nil, errorx.Rawf("cflag: invalid config line: %q", line)
+		}
+
+		name := strings.TrimSpace(line[:pos])
+		val := strings.TrimSpace(line[pos+1:])
+		val = strings.Trim(val, `"'`)
+		kv[name] = val
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, errorx.Raw(err.Error())
+	}
+	return kv, nil
+}
@@ -0,0 +1,79 @@
+package cflag
+
+import (
+	"strings"
+
+	"github.com/gookit/goutil/errorx"
+	"github.com/gookit/goutil/structs"
+)
+
+// OptCheckFn validator func for a bound flag option value. receives the
+// typed value from flag.Getter.Get(), returns a non-nil error on failure.
+type OptCheckFn func(val interface{}) error
+
+// FlagOpt extra metadata bound to a flag.Flag, used for help rendering,
+// validation and shell completion.
+type FlagOpt struct {
+	// Required mark the option value must be non-empty after parsing. A
+	// non-empty default, config file or ENV value also satisfies it.
+	Required bool
+	// Shortcuts alias names for the option, eg: ["n"] for "--name"
+	Shortcuts []string
+	// Validator run against the parsed option value.
+	Validator OptCheckFn
+	// Completer generate completion candidates for the option value.
+	// prefix is the partial value already typed by the user.
+	Completer func(prefix string) []string
+	// Repeatable mark the option as accepting multiple values, eg: a
+	// StringsVar/IntsVar/KVStringVar bound flag that can be given more
+	// than once on the command line.
+	Repeatable bool
+}
+
+// HelpName render the option name with its shortcuts for help/usage output.
+// eg: "--name, -n"
+func (o *FlagOpt) HelpName(name string) string {
+	full := AddPrefix(name)
+	if o == nil || len(o.Shortcuts) == 0 {
+		return full
+	}
+
+	names := make([]string, 0, len(o.Shortcuts)+1)
+	names = append(names, full)
+	for _, short := range o.Shortcuts {
+		names = append(names, AddPrefix(short))
+	}
+	return strings.Join(names, ", ")
+}
+
+// FlagArg a bound positional argument for a CFlags command.
+type FlagArg struct {
+	// Name of the argument
+	Name string
+	// Desc of the argument
+	Desc string
+	// Value typed holder, built from the default value passed to AddArg
+	Value *structs.Value
+	// Required mark the argument must be given
+	Required bool
+	// Index position among the bound arguments, 0-based
+	Index int
+	// V raw string value parsed from the command line
+	V string
+}
+
+// check the arg is valid for binding
+func (a *FlagArg) check() error {
+	if a.Name == "" {
+		return errorx.Raw("cflag: arg name cannot be empty")
+	}
+	return nil
+}
+
+// HelpDesc for the argument, marks required ones with a red "*"
+func (a *FlagArg) HelpDesc() string {
+	if a.Required {
+		return "<red>*</>" + a.Desc
+	}
+	return a.Desc
+}
@@ -0,0 +1,156 @@
+package cflag
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/strutil"
+)
+
+// hidden flag that selects the help renderer, see showHelp
+const flagHelpFormat = "help-format"
+
+// registerHelpFormatFlag install the hidden --help-format flag, so users can
+// run `mycmd --help-format=man | man -l -` during development.
+func (c *CFlags) registerHelpFormatFlag() {
+	if c.hiddenOpts == nil {
+		c.hiddenOpts = make(map[string]bool)
+	}
+
+	if c.Lookup(flagHelpFormat) == nil {
+		c.String(flagHelpFormat, "", "Render help as text|man|md, eg: --help-format=man | man -l -")
+		c.hiddenOpts[flagHelpFormat] = true
+	}
+}
+
+// helpFormat currently selected by --help-format, "" means the default text help.
+func (c *CFlags) helpFormat() string {
+	if fv := c.Lookup(flagHelpFormat); fv != nil {
+		return fv.Value.String()
+	}
+	return ""
+}
+
+// renderHelpInFormat write the man or markdown help to stdout.
+func (c *CFlags) renderHelpInFormat(format string) {
+	switch format {
+	case "man":
+		_ = c.RenderMan(os.Stdout)
+	case "md", "markdown":
+		_ = c.RenderMarkdown(os.Stdout)
+	default:
+		cliutil.Errorln("cflag: unknown --help-format '" + format + "'")
+	}
+}
+
+// RenderMan write a roff(7) man page for the command to w, built from Desc,
+// Version, Example, LongHelp, the bound options/arguments and, if any are
+// registered, the subcommands.
+func (c *CFlags) RenderMan(w io.Writer) error {
+	name := c.Name()
+
+	fmt.Fprintf(w, ".TH %s 1 \"\" \"%s\" \"User Commands\"\n", strings.ToUpper(name), c.Version)
+	fmt.Fprintf(w, ".SH NAME\n%s \\- %s\n", name, strutil.UpperFirst(c.Desc))
+
+	fmt.Fprintf(w, ".SH SYNOPSIS\n.B %s\n[\\fIOPTIONS\\fR]", name)
+	if len(c.commands) > 0 {
+		fmt.Fprint(w, " \\fICOMMAND\\fR")
+	}
+	for name, arg := range c.bindArgs {
+		if arg.Required {
+			fmt.Fprintf(w, " \\fI%s\\fR", name)
+		} else {
+			fmt.Fprintf(w, " [\\fI%s\\fR]", name)
+		}
+	}
+	fmt.Fprintln(w)
+
+	if c.LongHelp != "" {
+		fmt.Fprintf(w, ".SH DESCRIPTION\n%s\n", strings.Trim(c.LongHelp, "\n"))
+	}
+
+	fmt.Fprintln(w, ".SH OPTIONS")
+	c.VisitAll(func(f *flag.Flag) {
+		if c.hiddenOpts[f.Name] {
+			return
+		}
+		mate := c.bindOpts[f.Name]
+		fmt.Fprintf(w, ".TP\n\\fB%s\\fR\n%s\n", mate.HelpName(f.Name), color.ClearTag(f.Usage))
+	})
+
+	if len(c.bindArgs) > 0 {
+		fmt.Fprintln(w, ".SH ARGUMENTS")
+		for name, arg := range c.bindArgs {
+			fmt.Fprintf(w, ".TP\n\\fI%s\\fR\n%s\n", name, arg.Desc)
+		}
+	}
+
+	if len(c.commands) > 0 {
+		fmt.Fprintln(w, ".SH COMMANDS")
+		for _, cn := range c.cmdNames {
+			fmt.Fprintf(w, ".TP\n\\fB%s\\fR\n%s\n", cn, c.commands[cn].Desc)
+		}
+	}
+
+	if c.Example != "" {
+		fmt.Fprintf(w, ".SH EXAMPLES\n%s\n", strings.Trim(c.Example, "\n"))
+	}
+	return nil
+}
+
+// RenderMarkdown write a Markdown reference for the command to w, mirroring
+// the sections produced by RenderMan.
+func (c *CFlags) RenderMarkdown(w io.Writer) error {
+	name := c.Name()
+
+	fmt.Fprintf(w, "# %s\n\n", name)
+	if c.Desc != "" {
+		fmt.Fprintf(w, "%s\n\n", c.helpDesc())
+	}
+
+	fmt.Fprintln(w, "## Synopsis")
+	fmt.Fprintf(w, "\n```\n%s [OPTIONS]", name)
+	if len(c.commands) > 0 {
+		fmt.Fprint(w, " COMMAND")
+	}
+	fmt.Fprintln(w, "\n```")
+
+	if c.LongHelp != "" {
+		fmt.Fprintf(w, "\n## Description\n\n%s\n", strings.Trim(c.LongHelp, "\n"))
+	}
+
+	fmt.Fprint(w, "\n## Options\n\n")
+	fmt.Fprintln(w, "| Name | Usage | Default |")
+	fmt.Fprintln(w, "| --- | --- | --- |")
+	c.VisitAll(func(f *flag.Flag) {
+		if c.hiddenOpts[f.Name] {
+			return
+		}
+		mate := c.bindOpts[f.Name]
+		fmt.Fprintf(w, "| `%s` | %s | `%s` |\n", mate.HelpName(f.Name), color.ClearTag(f.Usage), f.DefValue)
+	})
+
+	if len(c.bindArgs) > 0 {
+		fmt.Fprint(w, "\n## Arguments\n\n")
+		for name, arg := range c.bindArgs {
+			fmt.Fprintf(w, "- **%s** - %s\n", name, arg.Desc)
+		}
+	}
+
+	if len(c.commands) > 0 {
+		fmt.Fprint(w, "\n## Commands\n\n")
+		for _, cn := range c.cmdNames {
+			fmt.Fprintf(w, "- **%s** - %s\n", cn, c.commands[cn].Desc)
+		}
+	}
+
+	if c.Example != "" {
+		fmt.Fprintf(w, "\n## Examples\n\n```\n%s\n```\n", strings.Trim(c.Example, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package cflag
+
+import (
+	"flag"
+	"reflect"
+)
+
+// AddPrefix add the "--"/"-" prefix for a flag or shortcut name. Names
+// longer than one byte get "--", single-byte shortcuts get "-".
+func AddPrefix(name string) string {
+	if len(name) > 1 {
+		return "--" + name
+	}
+	return "-" + name
+}
+
+// IsZeroValue determine whether value is the zero value for flag fl, and
+// whether fl.Value holds a string (so help can quote its default).
+//
+// from flag.isZeroValue, which is unexported in the std lib.
+func IsZeroValue(fl *flag.Flag, value string) (ok bool, isString bool) {
+	typ := reflect.TypeOf(fl.Value)
+	var z reflect.Value
+	if typ.Kind() == reflect.Pointer {
+		z = reflect.New(typ.Elem())
+	} else {
+		z = reflect.Zero(typ)
+	}
+
+	if getter, ok := z.Interface().(flag.Getter); ok {
+		if _, isStr := getter.Get().(string); isStr {
+			isString = true
+		}
+	}
+
+	return value == z.Interface().(flag.Value).String(), isString
+}
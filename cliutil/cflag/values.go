@@ -0,0 +1,169 @@
+package cflag
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gookit/goutil/errorx"
+	"github.com/gookit/goutil/stdutil"
+	"github.com/gookit/goutil/strutil"
+)
+
+// Strings a flag.Value that collects one or more string values, one per
+// `--name=value` occurrence on the command line.
+type Strings []string
+
+// String implements flag.Value
+func (s *Strings) String() string { return strings.Join(*s, ",") }
+
+// Set implements flag.Value, appends instead of replacing.
+func (s *Strings) Set(val string) error {
+	*s = append(*s, val)
+	return nil
+}
+
+// Get implements flag.Getter
+func (s *Strings) Get() interface{} { return []string(*s) }
+
+// Ints a flag.Value that collects one or more int values, one per
+// `--name=value` occurrence on the command line.
+type Ints []int
+
+// String implements flag.Value
+func (s *Ints) String() string {
+	parts := make([]string, len(*s))
+	for i, n := range *s {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set implements flag.Value, appends instead of replacing.
+func (s *Ints) Set(val string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil {
+		return errorx.Rawf("invalid int value %q", val)
+	}
+	*s = append(*s, n)
+	return nil
+}
+
+// Get implements flag.Getter
+func (s *Ints) Get() interface{} { return []int(*s) }
+
+// KVString a flag.Value that collects `key=value` pairs, one per
+// `--name=key=value` occurrence on the command line.
+type KVString map[string]string
+
+// String implements flag.Value
+func (m KVString) String() string {
+	parts := make([]string, 0, len(m))
+	for k, v := range m {
+		parts = append(parts, k+"="+v)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set implements flag.Value, parses "key=value" and merges into the map.
+func (m *KVString) Set(val string) error {
+	k, v, ok := strings.Cut(val, "=")
+	if !ok {
+		return errorx.Rawf("invalid key=value pair %q", val)
+	}
+
+	if *m == nil {
+		*m = make(KVString)
+	}
+	(*m)[k] = v
+	return nil
+}
+
+// Get implements flag.Getter
+func (m KVString) Get() interface{} { return map[string]string(m) }
+
+// EnumString a string flag.Value for EnumVar. Set accepts any value; the
+// allow-list is checked after parsing by the Validator that EnumVar installs.
+type EnumString string
+
+// String implements flag.Value
+func (e *EnumString) String() string { return string(*e) }
+
+// Set implements flag.Value
+func (e *EnumString) Set(val string) error {
+	*e = EnumString(val)
+	return nil
+}
+
+// Get implements flag.Getter
+func (e *EnumString) Get() interface{} { return string(*e) }
+
+// StringsVar binds p to a repeatable string option: `--name=a --name=b`
+// appends to p instead of overwriting it. def, if non-empty, is a
+// comma-separated initial value.
+func (c *CFlags) StringsVar(p *[]string, name, def, usage string) {
+	*p = nil
+	if def != "" {
+		*p = strutil.Split(def, ",")
+	}
+
+	c.Var((*Strings)(p), name, usage)
+	c.ConfigOpt(name, func(opt *FlagOpt) { opt.Repeatable = true })
+}
+
+// IntsVar binds p to a repeatable int option: `--name=1 --name=2` appends
+// to p instead of overwriting it. def, if non-empty, is a comma-separated
+// initial value.
+func (c *CFlags) IntsVar(p *[]int, name, def, usage string) {
+	*p = nil
+	for _, s := range strutil.Split(def, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+			*p = append(*p, n)
+		}
+	}
+
+	c.Var((*Ints)(p), name, usage)
+	c.ConfigOpt(name, func(opt *FlagOpt) { opt.Repeatable = true })
+}
+
+// KVStringVar binds p to a repeatable `key=value` option: `--name=a=1
+// --name=b=2` merges into p instead of overwriting it. def, if non-empty,
+// is a comma-separated list of initial "key=value" pairs.
+func (c *CFlags) KVStringVar(p *map[string]string, name, def, usage string) {
+	*p = make(map[string]string)
+	for _, kv := range strutil.Split(def, ",") {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			(*p)[k] = v
+		}
+	}
+
+	c.Var((*KVString)(p), name, usage)
+	c.ConfigOpt(name, func(opt *FlagOpt) { opt.Repeatable = true })
+}
+
+// EnumVar binds p to a string option restricted to allowed values, installing
+// a Validator so `--level=warn` fails cleanly when "warn" isn't in allowed.
+func (c *CFlags) EnumVar(p *string, name string, allowed []string, def, usage string) {
+	ok := false
+	for _, a := range allowed {
+		if def == a {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		stdutil.Panicf("cflag: EnumVar default %q for option '%s' is not in allowed values [%s]", def, name, strings.Join(allowed, ", "))
+	}
+
+	*p = def
+	c.Var((*EnumString)(p), name, usage)
+
+	c.AddValidator(name, func(val interface{}) error {
+		s, _ := val.(string)
+		for _, a := range allowed {
+			if s == a {
+				return nil
+			}
+		}
+		return errorx.Rawf("value must be one of [%s], got %q", strings.Join(allowed, ", "), s)
+	})
+}
@@ -57,9 +57,15 @@ func TextWidth(s string) int { return Utf8Width(s) }
 //	strutil.Utf8Width(str)	=> 7
 //	len(str) => 9
 //	len([]rune(str)) = utf8.RuneCountInString(s) => 5
+//
+// Utf8Width, Utf8Truncate, Utf8Split and WidthWrap are implemented in
+// utf8_width.go, where they skip ANSI escape sequences and iterate by
+// grapheme cluster instead of by rune.
 func Utf8Width(s string) (size int) {
-	for _, runeVal := range []rune(s) {
-		size += RuneWidth(runeVal)
+	for _, tok := range utf8Tokenize(s) {
+		if !tok.isEsc {
+			size += tok.width
+		}
 	}
 	return size
 }
@@ -67,90 +73,8 @@ func Utf8Width(s string) (size int) {
 // TextTruncate alias of the Utf8Truncate()
 func TextTruncate(s string, w int, tail string) string { return Utf8Truncate(s, w, tail) }
 
-// Utf8Truncate a string with given width.
-func Utf8Truncate(s string, w int, tail string) string {
-	if sw := Utf8Width(s); sw <= w {
-		return s
-	}
-
-	i := 0
-	r := []rune(s)
-	w -= TextWidth(tail)
-
-	tmpW := 0
-	for ; i < len(r); i++ {
-		cw := RuneWidth(r[i])
-		if tmpW+cw > w {
-			break
-		}
-		tmpW += cw
-	}
-	return string(r[0:i]) + tail
-}
-
 // TextSplit alias of the Utf8Split()
 func TextSplit(s string, w int) []string { return Utf8Split(s, w) }
 
-// Utf8Split split a string by width.
-func Utf8Split(s string, w int) (ss []string) {
-	if sw := Utf8Width(s); sw <= w {
-		return []string{s}
-	}
-
-	tmpW := 0
-	tmpS := ""
-	for _, r := range []rune(s) {
-		rw := RuneWidth(r)
-		if tmpW+rw == w {
-			tmpS += string(r)
-			ss = append(ss, tmpS)
-
-			tmpW, tmpS = 0, "" // reset
-			continue
-		}
-
-		if tmpW+rw > w {
-			ss = append(ss, tmpS)
-
-			// append to next line.
-			tmpW, tmpS = rw, string(r)
-			continue
-		}
-
-		tmpW += rw
-		tmpS += string(r)
-	}
-
-	if tmpW > 0 {
-		ss = append(ss, tmpS)
-	}
-	return
-}
-
 // TextWrap a string by "\n"
 func TextWrap(s string, w int) string { return WidthWrap(s, w) }
-
-// WidthWrap a string by "\n"
-func WidthWrap(s string, w int) string {
-	tmpW := 0
-	out := ""
-
-	for _, r := range []rune(s) {
-		cw := RuneWidth(r)
-		if r == '\n' {
-			out += string(r)
-			tmpW = 0
-			continue
-		} else if tmpW+cw > w {
-			out += "\n"
-			tmpW = 0
-			out += string(r)
-			tmpW += cw
-			continue
-		}
-
-		out += string(r)
-		tmpW += cw
-	}
-	return out
-}
@@ -0,0 +1,233 @@
+package strutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// zeroWidthJoiner glues two emoji into one grapheme cluster, eg: the family
+// emoji (man+ZWJ+woman+ZWJ+girl) renders as a single family glyph.
+const zeroWidthJoiner = '\u200d'
+
+// variationSelector16 picks the emoji-style glyph, it never adds width of
+// its own, eg: a heart symbol followed by VS16 renders as one wide glyph.
+const variationSelector16 = '\ufe0f'
+
+// utf8Token is one printable cell: either a grapheme cluster with its
+// display width, or a passthrough ANSI escape sequence with width 0.
+type utf8Token struct {
+	text  string
+	width int
+	isEsc bool
+}
+
+// utf8Tokenize split s into utf8Tokens: ANSI CSI/OSC escape sequences are
+// kept whole and marked isEsc so callers can pass them through untouched,
+// everything else is grouped by grapheme cluster (base rune plus any
+// combining marks, variation selectors, ZWJ-joined runes or a following
+// regional indicator forming a flag) so multi-rune clusters count as one
+// display cell.
+func utf8Tokenize(s string) []utf8Token {
+	rs := []rune(s)
+	toks := make([]utf8Token, 0, len(rs))
+
+	for i := 0; i < len(rs); {
+		if rs[i] == '\x1b' {
+			text, next := scanAnsiEscape(rs, i)
+			toks = append(toks, utf8Token{text: text, isEsc: true})
+			i = next
+			continue
+		}
+
+		start := i
+		w := RuneWidth(rs[i])
+		i++
+
+		for i < len(rs) {
+			r := rs[i]
+			switch {
+			case isCombiningMark(r) || r == variationSelector16:
+				i++
+			case r == zeroWidthJoiner && i+1 < len(rs):
+				i += 2 // ZWJ plus the rune it joins
+				if jw := RuneWidth(rs[i-1]); jw > w {
+					w = jw
+				}
+			case i == start+1 && isRegionalIndicator(rs[start]) && isRegionalIndicator(r):
+				i++
+				w = 2 // flag emoji: a pair of regional indicators renders as one wide cell
+			default:
+				goto done
+			}
+		}
+	done:
+		toks = append(toks, utf8Token{text: string(rs[start:i]), width: w})
+	}
+	return toks
+}
+
+// scanAnsiEscape consume one ANSI escape sequence starting at rs[i] (rs[i]
+// must be ESC), returning its text and the index right after it.
+//
+// Recognises CSI ("ESC[...final byte in 0x40-0x7E") and OSC
+// ("ESC]...BEL or ST"); any other rune after ESC is taken together with
+// the ESC as a two-rune escape so we never get stuck.
+func scanAnsiEscape(rs []rune, i int) (string, int) {
+	start := i
+	i++ // past ESC
+	if i >= len(rs) {
+		return string(rs[start:i]), i
+	}
+
+	switch rs[i] {
+	case '[': // CSI: ESC [ params... final
+		i++
+		for i < len(rs) && (rs[i] < 0x40 || rs[i] > 0x7e) {
+			i++
+		}
+		if i < len(rs) {
+			i++ // include the final byte
+		}
+	case ']': // OSC: ESC ] ... BEL | ESC \
+		i++
+		for i < len(rs) {
+			if rs[i] == '\a' {
+				i++
+				break
+			}
+			if rs[i] == '\x1b' && i+1 < len(rs) && rs[i+1] == '\\' {
+				i += 2
+				break
+			}
+			i++
+		}
+	default:
+		i++ // lone/unknown escape, skip just the one rune after ESC
+	}
+	return string(rs[start:i]), i
+}
+
+func isCombiningMark(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Mc, r) || unicode.Is(unicode.Me, r)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isSGRReset report whether text is a "reset" SGR escape, eg: "\x1b[0m" or "\x1b[m"
+func isSGRReset(text string) bool {
+	return text == "\x1b[0m" || text == "\x1b[m"
+}
+
+// isSGR report whether text is a Select Graphic Rendition CSI sequence,
+// the kind gookit/color uses to open/close colors and styles.
+func isSGR(text string) bool {
+	return strings.HasPrefix(text, "\x1b[") && strings.HasSuffix(text, "m")
+}
+
+// Utf8Truncate a string with given display width, skipping ANSI escape
+// sequences and never cutting inside one. If the truncated region opened
+// SGR color/style state, a trailing "\x1b[0m" reset is appended.
+func Utf8Truncate(s string, w int, tail string) string {
+	if sw := Utf8Width(s); sw <= w {
+		return s
+	}
+	w -= TextWidth(tail)
+
+	var b strings.Builder
+	used, openSGR := 0, false
+
+	for _, tok := range utf8Tokenize(s) {
+		if tok.isEsc {
+			b.WriteString(tok.text)
+			if isSGR(tok.text) {
+				openSGR = !isSGRReset(tok.text)
+			}
+			continue
+		}
+
+		if used+tok.width > w {
+			break
+		}
+		used += tok.width
+		b.WriteString(tok.text)
+	}
+
+	b.WriteString(tail)
+	if openSGR {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// Utf8Split split a string by display width, skipping ANSI escape sequences
+// and never cutting inside one or inside a grapheme cluster.
+func Utf8Split(s string, w int) (ss []string) {
+	if sw := Utf8Width(s); sw <= w {
+		return []string{s}
+	}
+
+	var b strings.Builder
+	used := 0
+
+	for _, tok := range utf8Tokenize(s) {
+		if tok.isEsc {
+			b.WriteString(tok.text)
+			continue
+		}
+
+		if used+tok.width == w {
+			b.WriteString(tok.text)
+			ss = append(ss, b.String())
+			b.Reset()
+			used = 0
+			continue
+		}
+
+		if used+tok.width > w {
+			ss = append(ss, b.String())
+			b.Reset()
+			b.WriteString(tok.text)
+			used = tok.width
+			continue
+		}
+
+		b.WriteString(tok.text)
+		used += tok.width
+	}
+
+	if used > 0 || b.Len() > 0 {
+		ss = append(ss, b.String())
+	}
+	return
+}
+
+// WidthWrap a string by "\n", skipping ANSI escape sequences and never
+// cutting inside one or inside a grapheme cluster.
+func WidthWrap(s string, w int) string {
+	var b strings.Builder
+	used := 0
+
+	for _, tok := range utf8Tokenize(s) {
+		if tok.isEsc {
+			b.WriteString(tok.text)
+			continue
+		}
+
+		if tok.text == "\n" {
+			b.WriteString(tok.text)
+			used = 0
+			continue
+		}
+
+		if used+tok.width > w {
+			b.WriteString("\n")
+			used = 0
+		}
+
+		b.WriteString(tok.text)
+		used += tok.width
+	}
+	return b.String()
+}